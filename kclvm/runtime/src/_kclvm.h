@@ -15,6 +15,18 @@
 extern "C" {
 #endif
 
+// must be equal to kclvm_abi_version() of the loaded runtime,
+// e.g. KCLVM_ABI_CHECK(dlsym(handle, "kclvm_abi_version")).
+// A NULL pointer (runtime without the symbol) is incompatible.
+
+#define KCLVM_ABI_VERSION 0xf7dfa989ffecf5dfULL
+
+static inline bool kclvm_abi_check(uint64_t (*abi_version_fn)(void)) {
+    return abi_version_fn && abi_version_fn() == KCLVM_ABI_VERSION;
+}
+
+#define KCLVM_ABI_CHECK(abi_version_fn) kclvm_abi_check((uint64_t (*)(void))(abi_version_fn))
+
 // please keep same as 'kclvm/runtime/src/kind/mod.rs#Kind'
 
 enum kclvm_kind_t {
@@ -82,6 +94,8 @@ typedef struct kclvm_value_ref_t kclvm_value_ref_t;
 
 typedef struct kclvm_value_t kclvm_value_t;
 
+uint64_t kclvm_abi_version();
+
 void kclvm_assert(kclvm_context_t* ctx, kclvm_value_ref_t* value, kclvm_value_ref_t* msg);
 
 kclvm_value_ref_t* kclvm_base64_decode(kclvm_context_t* ctx, kclvm_value_ref_t* args, kclvm_value_ref_t* _kwargs);
@@ -2,6 +2,8 @@
 
 ; Auto generated, DONOT EDIT!!!
 
+@KCLVM_ABI_VERSION = linkonce_odr constant i64 u0xf7dfa989ffecf5df
+
 %"kclvm_bool_t" = type i8
 
 %"kclvm_buffer_t" = type { i8* }
@@ -30,6 +32,8 @@
 
 %"kclvm_value_t" = type { i8* }
 
+declare i64 @kclvm_abi_version();
+
 declare void @kclvm_assert(%kclvm_context_t* %ctx, %kclvm_value_ref_t* %value, %kclvm_value_ref_t* %msg);
 
 declare %kclvm_value_ref_t* @kclvm_base64_decode(%kclvm_context_t* %ctx, %kclvm_value_ref_t* %args, %kclvm_value_ref_t* %_kwargs);
@@ -2,6 +2,9 @@
 
 // Auto generated, DONOT EDIT!!!
 
+/// Hash over the sorted runtime api-spec list, returned by kclvm_abi_version.
+pub const KCLVM_ABI_VERSION: u64 = 0xf7dfa989ffecf5df;
+
 #[allow(dead_code, non_camel_case_types)]
 #[derive(Clone, PartialEq, Eq, Debug, Hash)]
 pub enum ApiType {
@@ -26,6 +29,7 @@ impl ApiType {
 #[allow(dead_code, non_camel_case_types)]
 #[derive(Clone, PartialEq, Eq, Debug, Hash)]
 pub enum ApiFunc {
+    kclvm_abi_version,
     kclvm_assert,
     kclvm_base64_decode,
     kclvm_base64_encode,
@@ -2,9 +2,15 @@
 
 // Auto generated, DONOT EDIT!!!
 
+#[no_mangle]
+pub extern "C" fn kclvm_abi_version() -> u64 {
+    crate::KCLVM_ABI_VERSION
+}
+
 #[allow(dead_code)]
 pub fn _kclvm_get_fn_ptr_by_name(name: &str) -> u64 {
     match name {
+        "kclvm_abi_version" => crate::kclvm_abi_version as *const () as u64,
         "kclvm_assert" => crate::kclvm_assert as *const () as u64,
         "kclvm_base64_decode" => crate::kclvm_base64_decode as *const () as u64,
         "kclvm_base64_encode" => crate::kclvm_base64_encode as *const () as u64,
@@ -4,6 +4,8 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"flag"
 	"fmt"
 	"os"
@@ -25,83 +27,88 @@ var (
 func main() {
 	flag.Parse()
 
-	specList := LoadAllApiSpec(*flagRoot)
+	specList := WithAbiVersionSpec(LoadAllApiSpec(*flagRoot))
+	genData := GenData{
+		Specs:      specList,
+		AbiVersion: AbiVersion(specList),
+	}
+
 	if filename := *flagGenCApi; filename != "" {
-		src := genCApi(specList)
+		src := genCApi(genData)
 		if err := os.WriteFile(filename, []byte(src), 0666); err != nil {
 			panic(err)
 		}
 	}
 	if filename := *flagGenLLApi; filename != "" {
-		src := genLLApi(specList)
+		src := genLLApi(genData)
 		if err := os.WriteFile(filename, []byte(src), 0666); err != nil {
 			panic(err)
 		}
 	}
 	if filename := *flagGenRustApiEnum; filename != "" {
-		src := genRustApiEnum(specList)
+		src := genRustApiEnum(genData)
 		if err := os.WriteFile(filename, []byte(src), 0666); err != nil {
 			panic(err)
 		}
 	}
 	if filename := *flagGenRustApiAddr; filename != "" {
-		src := genRustApiAddr(specList)
+		src := genRustApiAddr(genData)
 		if err := os.WriteFile(filename, []byte(src), 0666); err != nil {
 			panic(err)
 		}
 	}
 }
 
-func genCApi(specs []ApiSpec) string {
+func genCApi(data GenData) string {
 	tmpl, err := template.New("c-api").Parse(tmplCApi)
 	if err != nil {
 		panic(err)
 	}
 
 	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, specs)
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
 		panic(err)
 	}
 	return fmtCode(buf.String())
 }
 
-func genLLApi(specs []ApiSpec) string {
+func genLLApi(data GenData) string {
 	tmpl, err := template.New("ll-api").Parse(tmplLLApi)
 	if err != nil {
 		panic(err)
 	}
 
 	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, specs)
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
 		panic(err)
 	}
 	return fmtCode(buf.String())
 }
 
-func genRustApiEnum(specs []ApiSpec) string {
+func genRustApiEnum(data GenData) string {
 	tmpl, err := template.New("rust-api-enum").Parse(tmplRustEnum)
 	if err != nil {
 		panic(err)
 	}
 
 	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, specs)
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
 		panic(err)
 	}
 	return fmtCode(buf.String())
 }
 
-func genRustApiAddr(specs []ApiSpec) string {
+func genRustApiAddr(data GenData) string {
 	tmpl, err := template.New("rust-api-addr").Parse(tmplRustAddr)
 	if err != nil {
 		panic(err)
 	}
 
 	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, specs)
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
 		panic(err)
 	}
@@ -126,6 +133,12 @@ const (
 	apiSpecPrefix_LLApi = "// api-spec(llvm):"
 )
 
+// GenData is the data passed to the code templates.
+type GenData struct {
+	Specs      []ApiSpec
+	AbiVersion uint64
+}
+
 type ApiSpec struct {
 	File   string
 	Line   int
@@ -202,8 +215,48 @@ func LoadAllApiSpec(root string) []ApiSpec {
 
 // ----------------------------------------------------------------------------
 
+// abiVersionSpec is the api-spec of the generated kclvm_abi_version function,
+// its body is emitted into the rust-api-addr file.
+var abiVersionSpec = ApiSpec{
+	Name:   "kclvm_abi_version",
+	SpecC:  "uint64_t kclvm_abi_version();",
+	SpecLL: "declare i64 @kclvm_abi_version();",
+}
+
+// WithAbiVersionSpec adds the generated kclvm_abi_version api to the sorted specs.
+func WithAbiVersionSpec(specs []ApiSpec) []ApiSpec {
+	for _, x := range specs {
+		if x.Name == abiVersionSpec.Name {
+			panic(fmt.Errorf("%s:%d '%s' is reserved for the generated api", x.File, x.Line, x.Name))
+		}
+	}
+
+	specs = append(specs, abiVersionSpec)
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+	return specs
+}
+
+// AbiVersion hashes the api-spec list sorted by name, so that any added,
+// removed or changed api results in a different version.
+func AbiVersion(specs []ApiSpec) uint64 {
+	sorted := append([]ApiSpec(nil), specs...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	h := sha256.New()
+	for _, spec := range sorted {
+		fmt.Fprintf(h, "%s\n%s\n%s\n", spec.Name, spec.SpecC, spec.SpecLL)
+	}
+	return binary.BigEndian.Uint64(h.Sum(nil))
+}
+
+// ----------------------------------------------------------------------------
+
 const tmplCApi = `
-{{$specList := .}}
+{{$specList := .Specs}}
 
 // Copyright The KCL Authors. All rights reserved.
 
@@ -222,6 +275,18 @@ const tmplCApi = `
 extern "C" {
 #endif
 
+// must be equal to kclvm_abi_version() of the loaded runtime,
+// e.g. KCLVM_ABI_CHECK(dlsym(handle, "kclvm_abi_version")).
+// A NULL pointer (runtime without the symbol) is incompatible.
+
+#define KCLVM_ABI_VERSION {{printf "0x%016x" .AbiVersion}}ULL
+
+static inline bool kclvm_abi_check(uint64_t (*abi_version_fn)(void)) {
+    return abi_version_fn && abi_version_fn() == KCLVM_ABI_VERSION;
+}
+
+#define KCLVM_ABI_CHECK(abi_version_fn) kclvm_abi_check((uint64_t (*)(void))(abi_version_fn))
+
 // please keep same as 'kclvm/runtime/src/kind/mod.rs#Kind'
 
 enum kclvm_kind_t {
@@ -279,12 +344,14 @@ enum kclvm_kind_t {
 // ----------------------------------------------------------------------------
 
 const tmplLLApi = `
-{{$specList := .}}
+{{$specList := .Specs}}
 
 ; Copyright The KCL Authors. All rights reserved.
 
 ; Auto generated, DONOT EDIT!!!
 
+@KCLVM_ABI_VERSION = linkonce_odr constant i64 {{printf "u0x%016x" .AbiVersion}}
+
 {{range $_, $spec := $specList}}
 {{if ($spec.IsType)}}{{$spec.SpecLL}}{{end}}
 {{end}}
@@ -302,12 +369,15 @@ define void @__kcl_keep_link_runtime(%kclvm_value_ref_t* %_a, %kclvm_context_t*
 // ----------------------------------------------------------------------------
 
 const tmplRustEnum = `
-{{$specList := .}}
+{{$specList := .Specs}}
 
 // Copyright The KCL Authors. All rights reserved.
 
 // Auto generated, DONOT EDIT!!!
 
+/// Hash over the sorted runtime api-spec list, returned by kclvm_abi_version.
+pub const KCLVM_ABI_VERSION: u64 = {{printf "0x%016x" .AbiVersion}};
+
 #[allow(dead_code, non_camel_case_types)]
 #[derive(Clone, PartialEq, Eq, Debug, Hash)]
 pub enum ApiType {
@@ -354,12 +424,17 @@ impl ApiFunc {
 // ----------------------------------------------------------------------------
 
 const tmplRustAddr = `
-{{$specList := .}}
+{{$specList := .Specs}}
 
 // Copyright The KCL Authors. All rights reserved.
 
 // Auto generated, DONOT EDIT!!!
 
+#[no_mangle]
+pub extern "C" fn kclvm_abi_version() -> u64 {
+	crate::KCLVM_ABI_VERSION
+}
+
 #[allow(dead_code)]
 pub fn _kclvm_get_fn_ptr_by_name(name: &str) -> u64 {
 	match name {
@@ -0,0 +1,133 @@
+// Copyright The KCL Authors. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var testSpecList = []ApiSpec{
+	{
+		File:   "context/api.rs",
+		Line:   45,
+		Name:   "kclvm_context_new",
+		SpecC:  "kclvm_context_t* kclvm_context_new();",
+		SpecLL: "declare %kclvm_context_t* @kclvm_context_new();",
+	},
+	{
+		File:   "value/api.rs",
+		Line:   120,
+		Name:   "kclvm_value_None",
+		SpecC:  "kclvm_value_ref_t* kclvm_value_None(kclvm_context_t* ctx);",
+		SpecLL: "declare %kclvm_value_ref_t* @kclvm_value_None(%kclvm_context_t* %ctx);",
+	},
+}
+
+func cloneSpecList() []ApiSpec {
+	return append([]ApiSpec(nil), testSpecList...)
+}
+
+func TestAbiVersion_stable(t *testing.T) {
+	want := AbiVersion(cloneSpecList())
+	if got := AbiVersion(cloneSpecList()); got != want {
+		t.Fatalf("expect %#x, got %#x", want, got)
+	}
+
+	moved := cloneSpecList()
+	for i := range moved {
+		moved[i].File = "moved/" + moved[i].File
+		moved[i].Line += 100
+	}
+	if got := AbiVersion(moved); got != want {
+		t.Fatalf("File/Line changed the abi version: expect %#x, got %#x", want, got)
+	}
+}
+
+func TestAbiVersion_reordered(t *testing.T) {
+	want := AbiVersion(cloneSpecList())
+
+	reordered := cloneSpecList()
+	reordered[0], reordered[1] = reordered[1], reordered[0]
+	if got := AbiVersion(reordered); got != want {
+		t.Fatalf("order changed the abi version: expect %#x, got %#x", want, got)
+	}
+}
+
+func TestAbiVersion_changed(t *testing.T) {
+	base := AbiVersion(cloneSpecList())
+
+	for _, tt := range []struct {
+		name string
+		edit func(spec *ApiSpec)
+	}{
+		{"Name", func(spec *ApiSpec) { spec.Name += "_v2" }},
+		{"SpecC", func(spec *ApiSpec) { spec.SpecC = "void kclvm_context_new();" }},
+		{"SpecLL", func(spec *ApiSpec) { spec.SpecLL = "declare void @kclvm_context_new();" }},
+	} {
+		specs := cloneSpecList()
+		tt.edit(&specs[0])
+		if got := AbiVersion(specs); got == base {
+			t.Fatalf("%s changed but the abi version is still %#x", tt.name, got)
+		}
+	}
+}
+
+func TestWithAbiVersionSpec_reserved(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expect panic for user-defined kclvm_abi_version")
+		}
+	}()
+
+	specs := append(cloneSpecList(), ApiSpec{
+		File:   "abi/api.rs",
+		Line:   1,
+		Name:   "kclvm_abi_version",
+		SpecC:  "uint64_t kclvm_abi_version();",
+		SpecLL: "declare i64 @kclvm_abi_version();",
+	})
+	WithAbiVersionSpec(specs)
+}
+
+func TestGenApi_abiVersion(t *testing.T) {
+	specs := WithAbiVersionSpec(cloneSpecList())
+	data := GenData{
+		Specs:      specs,
+		AbiVersion: AbiVersion(specs),
+	}
+
+	for _, tt := range []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"c-api", genCApi(data), []string{
+			fmt.Sprintf("#define KCLVM_ABI_VERSION 0x%016xULL", data.AbiVersion),
+			"uint64_t kclvm_abi_version();",
+			"kclvm_context_t* kclvm_context_new();",
+		}},
+		{"ll-api", genLLApi(data), []string{
+			fmt.Sprintf("@KCLVM_ABI_VERSION = linkonce_odr constant i64 u0x%016x", data.AbiVersion),
+			"declare i64 @kclvm_abi_version();",
+			"declare %kclvm_context_t* @kclvm_context_new();",
+		}},
+		{"rust-api-enum", genRustApiEnum(data), []string{
+			fmt.Sprintf("pub const KCLVM_ABI_VERSION: u64 = 0x%016x;", data.AbiVersion),
+			"\tkclvm_abi_version,\n",
+			"\tkclvm_context_new,\n",
+		}},
+		{"rust-api-addr", genRustApiAddr(data), []string{
+			"#[no_mangle]\npub extern \"C\" fn kclvm_abi_version() -> u64 {\n\tcrate::KCLVM_ABI_VERSION\n}",
+			`"kclvm_abi_version" => crate::kclvm_abi_version as *const () as u64,`,
+			`"kclvm_context_new" => crate::kclvm_context_new as *const () as u64,`,
+		}},
+	} {
+		for _, want := range tt.want {
+			if !strings.Contains(tt.src, want) {
+				t.Errorf("%s: missing %q in:\n%s", tt.name, want, tt.src)
+			}
+		}
+	}
+}